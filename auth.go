@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"       // Provides context handling for request-scoped values and cancellation signals.
+	"encoding/json" // For JSON encoding and decoding.
+	"net/http"      // For HTTP client and server implementations.
+	"strings"       // For string manipulations.
+	"time"          // For time-related functions.
+
+	"errors" // For defining sentinel errors.
+
+	"github.com/dgrijalva/jwt-go"                // For minting and validating JWTs.
+	"github.com/go-chi/chi"                      // Lightweight, idiomatic router for building Go HTTP services.
+	"github.com/thedevsaddam/renderer"           // For rendering JSON and HTML responses.
+	"go.mongodb.org/mongo-driver/bson"           // For BSON handling in MongoDB.
+	"go.mongodb.org/mongo-driver/bson/primitive" // For MongoDB ObjectID handling.
+	"go.mongodb.org/mongo-driver/mongo"          // For mongo.IsDuplicateKeyError.
+	"go.mongodb.org/mongo-driver/mongo/options"  // For the unique index option.
+	"golang.org/x/crypto/bcrypt"                 // For hashing and verifying passwords.
+)
+
+// errInvalidToken is returned when a JWT fails validation, shared by the
+// header-based and cookie-based auth paths.
+var errInvalidToken = errors.New("invalid or expired token")
+
+const (
+	userCollectionName string        = "users"        // Collection storing user accounts.
+	jwtIssuer          string        = "golang-todo"  // Issuer claim embedded in minted tokens.
+	tokenTTL           time.Duration = 24 * time.Hour // How long a minted token stays valid.
+)
+
+// jwtSecret signs and verifies every JWT. It is published by main() from
+// Config.JWTSecret, which has no default, so the process refuses to start
+// without one set in the environment.
+var jwtSecret string
+
+type (
+	userModel struct {
+		ID           primitive.ObjectID `bson:"_id,omitempty"` // MongoDB ObjectID.
+		Email        string             `bson:"email"`         // Unique login identifier.
+		PasswordHash string             `bson:"passwordHash"`  // bcrypt hash of the user's password.
+		CreatedAt    time.Time          `bson:"createAt"`      // Creation timestamp.
+	}
+	authRequest struct {
+		Email    string `json:"email"`    // Email supplied at register/login time.
+		Password string `json:"password"` // Plaintext password supplied at register/login time.
+	}
+	claims struct {
+		UserID string `json:"userId"` // Hex-encoded ObjectID of the authenticated user.
+		jwt.StandardClaims
+	}
+)
+
+type contextKey string
+
+const userIDContextKey contextKey = "userID" // Key under which the authenticated user's ID is stored in the request context.
+
+// ensureUserIndexes creates the unique index on email that registerHandler's
+// duplicate-key handling relies on. Mirrors newMongoStore's index setup for
+// the todo collection; called once from main() at startup.
+func ensureUserIndexes(ctx context.Context) error {
+	_, err := db.Collection(userCollectionName).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+func registerHandler(w http.ResponseWriter, r *http.Request) {
+	var a authRequest
+	if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+		httpError(w, http.StatusBadRequest, errCodeBadRequest, "request body is not valid JSON", err) // Decodes the request body and checks for errors.
+		return
+	}
+	if a.Email == "" || a.Password == "" {
+		httpError(w, http.StatusBadRequest, errCodeValidation, "email and password are required", nil) // Checks for missing credentials.
+		return
+	}
+
+	users := db.Collection(userCollectionName)
+	existingStart := time.Now()
+	existing := users.FindOne(context.TODO(), bson.M{"email": a.Email})
+	observeMongoOp("FindOne", existingStart)
+	if existing.Err() == nil {
+		httpError(w, http.StatusConflict, errCodeConflict, "an account with that email already exists", nil) // Rejects duplicate registrations.
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(a.Password), bcrypt.DefaultCost) // Hashes the password.
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to hash password", err)
+		return
+	}
+
+	u := userModel{
+		ID:           primitive.NewObjectID(),
+		Email:        a.Email,
+		PasswordHash: string(hash),
+		CreatedAt:    time.Now(),
+	}
+	insertStart := time.Now()
+	_, err = users.InsertOne(context.TODO(), u)
+	observeMongoOp("InsertOne", insertStart)
+	if mongo.IsDuplicateKeyError(err) {
+		// The FindOne check above is a fast path; the unique index on email
+		// is what actually closes the race between two concurrent registers.
+		httpError(w, http.StatusConflict, errCodeConflict, "an account with that email already exists", nil)
+		return
+	}
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to create user", err)
+		return
+	}
+
+	token, err := mintToken(u.ID)
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to mint token", err)
+		return
+	}
+
+	rnd.JSON(w, http.StatusCreated, renderer.M{
+		"message": "account created successfully",
+		"token":   token,
+	})
+}
+
+func loginHandler(w http.ResponseWriter, r *http.Request) {
+	var a authRequest
+	if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+		httpError(w, http.StatusBadRequest, errCodeBadRequest, "request body is not valid JSON", err)
+		return
+	}
+
+	u, err := authenticate(a.Email, a.Password)
+	if err != nil {
+		httpError(w, http.StatusUnauthorized, errCodeUnauthorized, "invalid email or password", nil) // Never reveal whether the email or the password was wrong.
+		return
+	}
+
+	token, err := mintToken(u.ID)
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to mint token", err)
+		return
+	}
+
+	rnd.JSON(w, http.StatusOK, renderer.M{
+		"message": "logged in successfully",
+		"token":   token,
+	})
+}
+
+// authenticate looks up the user with the given email and verifies the
+// supplied password, shared by the JSON login handler and the UI login form.
+func authenticate(email, password string) (userModel, error) {
+	users := db.Collection(userCollectionName)
+	var u userModel
+	start := time.Now()
+	err := users.FindOne(context.TODO(), bson.M{"email": email}).Decode(&u)
+	observeMongoOp("FindOne", start)
+	if err != nil {
+		return userModel{}, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)); err != nil {
+		return userModel{}, err
+	}
+	return u, nil
+}
+
+func mintToken(userID primitive.ObjectID) (string, error) {
+	now := time.Now()
+	c := claims{
+		UserID: userID.Hex(),
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    jwtIssuer,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(tokenTTL).Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, c)
+	return token.SignedString([]byte(jwtSecret))
+}
+
+// authMiddleware validates the Authorization: Bearer <token> header on every
+// request and injects the authenticated user's ObjectID into the context.
+func authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if !strings.HasPrefix(header, "Bearer ") {
+			httpError(w, http.StatusUnauthorized, errCodeUnauthorized, "missing or malformed Authorization header", nil)
+			return
+		}
+		raw := strings.TrimPrefix(header, "Bearer ")
+
+		c := &claims{}
+		token, err := parseToken(raw, c)
+		if err != nil || !token.Valid {
+			httpError(w, http.StatusUnauthorized, errCodeUnauthorized, "invalid or expired token", err)
+			return
+		}
+
+		userID, err := primitive.ObjectIDFromHex(c.UserID)
+		if err != nil {
+			httpError(w, http.StatusUnauthorized, errCodeUnauthorized, "invalid token subject", err)
+			return
+		}
+
+		setRequestUser(r, userID.Hex()) // Lets requestLogger report the caller even though it ran before auth.
+		ctx := context.WithValue(r.Context(), userIDContextKey, userID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// parseToken validates a raw JWT string against jwtSecret and unmarshals its
+// claims, shared by the header-based and cookie-based auth paths.
+func parseToken(raw string, c *claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(raw, c, func(t *jwt.Token) (interface{}, error) {
+		return []byte(jwtSecret), nil
+	})
+}
+
+// userIDFromContext extracts the authenticated user's ObjectID set by authMiddleware.
+func userIDFromContext(r *http.Request) (primitive.ObjectID, bool) {
+	userID, ok := r.Context().Value(userIDContextKey).(primitive.ObjectID)
+	return userID, ok
+}
+
+func authHandlers() http.Handler {
+	rg := chi.NewRouter()
+	rg.Post("/register", registerHandler)
+	rg.Post("/login", loginHandler)
+	return rg
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"net/http" // For HTTP client and server implementations.
+
+	"github.com/rs/zerolog/log"        // Structured, leveled logging.
+	"github.com/thedevsaddam/renderer" // For rendering JSON and HTML responses.
+)
+
+// Error codes shared across handlers so clients can branch on a stable
+// string instead of parsing the message.
+const (
+	errCodeValidation   string = "validation_error"
+	errCodeBadRequest   string = "bad_request"
+	errCodeUnauthorized string = "unauthorized"
+	errCodeForbidden    string = "forbidden"
+	errCodeConflict     string = "conflict"
+	errCodeInternal     string = "internal_error"
+)
+
+// httpError writes the error envelope {"error":{"code","message","details"}}
+// every handler uses, and logs the underlying error (if any) server-side
+// instead of leaking it to the client.
+func httpError(w http.ResponseWriter, status int, code, message string, err error) {
+	if err != nil {
+		log.Error().Str("code", code).Err(err).Msg("request failed") // Keeps the raw error out of the response body.
+	}
+	rnd.JSON(w, status, renderer.M{
+		"error": renderer.M{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+// httpValidationError writes the error envelope with a details array
+// describing which fields failed validation and why.
+func httpValidationError(w http.ResponseWriter, details []validationFailure) {
+	rnd.JSON(w, http.StatusBadRequest, renderer.M{
+		"error": renderer.M{
+			"code":    errCodeValidation,
+			"message": "validation failed",
+			"details": details,
+		},
+	})
+}
@@ -3,150 +3,203 @@ package main
 import (
 	"context"       // Provides context handling for request-scoped values and cancellation signals.
 	"encoding/json" // For JSON encoding and decoding.
-	"log"           // For logging errors and other information.
+	"fmt"           // For formatting validation error messages.
 	"net/http"      // For HTTP client and server implementations.
 	"os"            // For operating system functionalities like signals.
 	"os/signal"     // For handling OS signals.
+	"strconv"       // For parsing numeric query params.
 	"strings"       // For string manipulations.
+	"syscall"       // For handling SIGTERM from container orchestrators.
 	"time"          // For time-related functions.
 
-	"github.com/go-chi/chi"                      // Lightweight, idiomatic router for building Go HTTP services.
-	"github.com/go-chi/chi/middleware"           // Middleware for chi router.
-	"github.com/thedevsaddam/renderer"           // For rendering JSON and HTML responses.
-	"go.mongodb.org/mongo-driver/bson"           // For BSON handling in MongoDB.
-	"go.mongodb.org/mongo-driver/bson/primitive" // For MongoDB ObjectID handling.
-	"go.mongodb.org/mongo-driver/mongo"          // MongoDB driver.
-	"go.mongodb.org/mongo-driver/mongo/options"  // For MongoDB client options.
+	"github.com/go-chi/chi"                                   // Lightweight, idiomatic router for building Go HTTP services.
+	"github.com/go-chi/chi/middleware"                        // Middleware for chi router.
+	"github.com/prometheus/client_golang/prometheus/promhttp" // Exposes /metrics for Prometheus to scrape.
+	"github.com/rs/zerolog/log"                               // Structured, leveled logging.
+	"github.com/thedevsaddam/renderer"                        // For rendering JSON and HTML responses.
+	"go.mongodb.org/mongo-driver/bson/primitive"              // For MongoDB ObjectID handling.
+	"go.mongodb.org/mongo-driver/mongo"                       // MongoDB driver.
 )
 
 var rnd *renderer.Render // Renderer for handling JSON and HTML responses.
-var db *mongo.Database   // MongoDB database instance.
-
-const (
-	hostName       string = "mongodb://127.0.0.1:27017" // MongoDB connection URI.
-	dbName         string = "demo_todo"                 // Database name.
-	collectionName string = "todo"                      // Collection name.
-	port           string = ":9000"                     // Server port.
-)
+var db *mongo.Database   // MongoDB database instance, always used for auth regardless of STORAGE.
+var store TodoStore      // Backend selected by the STORAGE env var; handlers only depend on this.
 
 type (
 	todoModel struct {
-		ID        primitive.ObjectID `bson:"_id,omitempty"` // MongoDB ObjectID.
-		Title     string             `bson:"title"`         // Title of the to-do item.
-		Completed bool               `bson:"completed"`     // Completion status.
-		CreatedAt time.Time          `bson:"createAt"`      // Creation timestamp.
+		ID        primitive.ObjectID `bson:"_id,omitempty"`     // MongoDB ObjectID.
+		OwnerID   primitive.ObjectID `bson:"ownerId"`           // ObjectID of the user the todo belongs to.
+		Title     string             `bson:"title"`             // Title of the to-do item.
+		Completed bool               `bson:"completed"`         // Completion status.
+		CreatedAt time.Time          `bson:"createAt"`          // Creation timestamp.
+		DueDate   *time.Time         `bson:"dueDate,omitempty"` // Optional due date.
 	}
 	todo struct {
-		ID        string    `json:"id"`        // ID as a string for JSON responses.
-		Title     string    `json:"title"`     // Title of the to-do item.
-		Completed bool      `json:"completed"` // Completion status.
-		CreatedAt time.Time `json:"createAt"`  // Creation timestamp.
+		ID        string     `json:"id"`                                      // ID as a string for JSON responses.
+		Title     string     `json:"title" validate:"required,min=1,max=200"` // Title of the to-do item.
+		Completed bool       `json:"completed"`                               // Completion status.
+		CreatedAt time.Time  `json:"createAt"`                                // Creation timestamp.
+		DueDate   *time.Time `json:"dueDate,omitempty"`                       // Optional due date; validateDueDate checks it against the real creation time, not this field.
 	}
 )
 
-func init() {
-	rnd = renderer.New()                                        // Initializes the renderer.
-	clientOptions := options.Client().ApplyURI(hostName)        // Sets MongoDB client options.
-	client, err := mongo.Connect(context.TODO(), clientOptions) // Connects to MongoDB.
-	checkErr(err)                                               // Checks for connection errors.
-	err = client.Ping(context.TODO(), nil)                      // Pings the MongoDB server.
-	checkErr(err)                                               // Checks for ping errors.
-	db = client.Database(dbName)                                // Selects the database.
-}
-
 func homeHandler(w http.ResponseWriter, r *http.Request) {
-	err := rnd.Template(w, http.StatusOK, []string{"static/home.tpl"}, nil) // Renders the home template.
-	checkErr(err)                                                           // Checks for rendering errors.
+	if err := rnd.Template(w, http.StatusOK, []string{"static/home.tpl"}, nil); err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to render page", err)
+	}
 }
 
 func fetchTodos(w http.ResponseWriter, r *http.Request) {
-	collection := db.Collection(collectionName)              // Gets the collection.
-	cursor, err := collection.Find(context.TODO(), bson.M{}) // Finds all documents.
+	ownerID, ok := userIDFromContext(r) // Resolves the authenticated user from the request context.
+	if !ok {
+		httpError(w, http.StatusForbidden, errCodeForbidden, "could not resolve authenticated user", nil)
+		return
+	}
+
+	opts, err := parseListOptions(r)
 	if err != nil {
-		rnd.JSON(w, http.StatusInternalServerError, renderer.M{
-			"message": "failed to fetch todos",
-			"error":   err,
-		}) // Responds with an error if the find operation fails.
+		httpError(w, http.StatusBadRequest, errCodeBadRequest, err.Error(), err) // Responds with an error if a query param is malformed.
 		return
 	}
-	defer cursor.Close(context.TODO()) // Ensures the cursor is closed.
 
-	var todos []todoModel // Slice to hold todos.
-	if err := cursor.All(context.TODO(), &todos); err != nil {
-		rnd.JSON(w, http.StatusInternalServerError, renderer.M{
-			"message": "failed to decode todos",
-			"error":   err,
-		}) // Responds with an error if decoding fails.
+	todos, total, err := store.List(context.TODO(), ownerID.Hex(), opts) // Lists todos owned by the caller.
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to fetch todos", err)
 		return
 	}
 
 	var todoList []todo // Slice to hold the response todos.
 	for _, t := range todos {
 		todoList = append(todoList, todo{
-			ID:        t.ID.Hex(),
+			ID:        t.ID,
 			Title:     t.Title,
 			Completed: t.Completed,
 			CreatedAt: t.CreatedAt,
+			DueDate:   t.DueDate,
 		}) // Converts todos to the response format.
 	}
+
+	pages := int((total + int64(opts.Limit) - 1) / int64(opts.Limit))
 	rnd.JSON(w, http.StatusOK, renderer.M{
 		"data": todoList,
-	}) // Responds with the list of todos.
+		"meta": renderer.M{
+			"page":  opts.Page,
+			"limit": opts.Limit,
+			"total": total,
+			"pages": pages,
+		},
+	}) // Responds with the paginated list of todos.
+}
+
+// parseListOptions translates the ?page=&limit=&sort=&order=&completed=&q=
+// query params into a ListOptions, defaulting to page 1, a limit of 20,
+// sorted by creation time descending.
+func parseListOptions(r *http.Request) (ListOptions, error) {
+	q := r.URL.Query()
+	opts := ListOptions{Page: 1, Limit: 20, SortField: "createAt", SortDesc: true}
+
+	if v := q.Get("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			return ListOptions{}, fmt.Errorf("page must be a positive integer")
+		}
+		opts.Page = page
+	}
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxListLimit {
+			return ListOptions{}, fmt.Errorf("limit must be between 1 and %d", maxListLimit)
+		}
+		opts.Limit = limit
+	}
+
+	if v := q.Get("sort"); v != "" {
+		if v != "createAt" && v != "title" {
+			return ListOptions{}, fmt.Errorf("sort must be one of createAt, title")
+		}
+		opts.SortField = v
+	}
+
+	if v := q.Get("order"); v != "" {
+		switch v {
+		case "asc":
+			opts.SortDesc = false
+		case "desc":
+			opts.SortDesc = true
+		default:
+			return ListOptions{}, fmt.Errorf("order must be one of asc, desc")
+		}
+	}
+
+	if v := q.Get("completed"); v != "" {
+		completed, err := strconv.ParseBool(v)
+		if err != nil {
+			return ListOptions{}, fmt.Errorf("completed must be a boolean")
+		}
+		opts.Completed = &completed
+	}
+
+	opts.Query = strings.TrimSpace(q.Get("q"))
+	return opts, nil
 }
 
 func createTodo(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := userIDFromContext(r) // Resolves the authenticated user from the request context.
+	if !ok {
+		httpError(w, http.StatusForbidden, errCodeForbidden, "could not resolve authenticated user", nil)
+		return
+	}
+
 	var t todo
 	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
-		rnd.JSON(w, http.StatusBadRequest, err) // Decodes the request body and checks for errors.
+		httpError(w, http.StatusBadRequest, errCodeBadRequest, "request body is not valid JSON", err) // Decodes the request body and checks for errors.
+		return
+	}
+	if err := validate.Struct(t); err != nil {
+		httpValidationError(w, validationFailures(err)) // Rejects a missing/too-long title.
 		return
 	}
-	if t.Title == "" {
-		rnd.JSON(w, http.StatusBadRequest, renderer.M{
-			"message": "The title is required",
-		}) // Checks for missing title.
+	now := time.Now()
+	if failures := validateDueDate(t.DueDate, now); failures != nil {
+		httpValidationError(w, failures) // Rejects a due date that isn't after the real creation time.
 		return
 	}
 
-	tm := todoModel{
-		ID:        primitive.NewObjectID(), // Creates a new ObjectID.
+	created, err := store.Create(context.TODO(), Todo{
+		OwnerID:   ownerID.Hex(),
 		Title:     t.Title,
 		Completed: false,
-		CreatedAt: time.Now(),
-	}
-
-	collection := db.Collection(collectionName)        // Gets the collection.
-	_, err := collection.InsertOne(context.TODO(), tm) // Inserts the new todo.
+		CreatedAt: now,
+		DueDate:   t.DueDate,
+	}) // Creates the new todo via the selected store.
 	if err != nil {
-		rnd.JSON(w, http.StatusInternalServerError, renderer.M{
-			"message": "Failed to save todo",
-			"error":   err,
-		}) // Responds with an error if the insert operation fails.
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to save todo", err)
 		return
 	}
 
 	rnd.JSON(w, http.StatusCreated, renderer.M{
 		"message": "Todo created successfully",
-		"todo_id": tm.ID.Hex(),
+		"todo_id": created.ID,
 	}) // Responds with success.
 }
 
 func deleteTodo(w http.ResponseWriter, r *http.Request) {
-	id := strings.TrimSpace(chi.URLParam(r, "id")) // Gets and trims the ID from the URL.
-	objID, err := primitive.ObjectIDFromHex(id)    // Converts the ID to ObjectID.
-	if err != nil {
-		rnd.JSON(w, http.StatusBadRequest, renderer.M{
-			"message": "The Id is invalid",
-		}) // Responds with an error if the ID is invalid.
+	ownerID, ok := userIDFromContext(r) // Resolves the authenticated user from the request context.
+	if !ok {
+		httpError(w, http.StatusForbidden, errCodeForbidden, "could not resolve authenticated user", nil)
 		return
 	}
 
-	collection := db.Collection(collectionName)                         // Gets the collection.
-	_, err = collection.DeleteOne(context.TODO(), bson.M{"_id": objID}) // Deletes the document.
+	id := strings.TrimSpace(chi.URLParam(r, "id")) // Gets and trims the ID from the URL.
+
+	err := store.Delete(context.TODO(), ownerID.Hex(), id) // Deletes only if owned by the caller.
+	if err == ErrTodoNotFound {
+		httpError(w, http.StatusForbidden, errCodeForbidden, "you do not have access to this todo", nil) // Rejects cross-user access instead of revealing whether the todo exists.
+		return
+	}
 	if err != nil {
-		rnd.JSON(w, http.StatusInternalServerError, renderer.M{
-			"message": "failed to delete todo",
-			"error":   err,
-		}) // Responds with an error if the delete operation fails.
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to delete todo", err)
 		return
 	}
 
@@ -156,39 +209,45 @@ func deleteTodo(w http.ResponseWriter, r *http.Request) {
 }
 
 func updateTodo(w http.ResponseWriter, r *http.Request) {
-	id := strings.TrimSpace(chi.URLParam(r, "id")) // Gets and trims the ID from the URL.
-	objID, err := primitive.ObjectIDFromHex(id)    // Converts the ID to ObjectID.
-	if err != nil {
-		rnd.JSON(w, http.StatusBadRequest, renderer.M{
-			"message": "The id is invalid",
-		}) // Responds with an error if the ID is invalid.
+	ownerID, ok := userIDFromContext(r) // Resolves the authenticated user from the request context.
+	if !ok {
+		httpError(w, http.StatusForbidden, errCodeForbidden, "could not resolve authenticated user", nil)
 		return
 	}
 
+	id := strings.TrimSpace(chi.URLParam(r, "id")) // Gets and trims the ID from the URL.
+
 	var t todo
 	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
-		rnd.JSON(w, http.StatusBadRequest, err) // Decodes the request body and checks for errors.
+		httpError(w, http.StatusBadRequest, errCodeBadRequest, "request body is not valid JSON", err) // Decodes the request body and checks for errors.
+		return
+	}
+	if err := validate.Struct(t); err != nil {
+		httpValidationError(w, validationFailures(err)) // Rejects a missing/too-long title.
 		return
 	}
 
-	if t.Title == "" {
-		rnd.JSON(w, http.StatusBadRequest, renderer.M{
-			"message": "The title field is missing",
-		}) // Checks for missing title.
+	current, err := store.Get(context.TODO(), ownerID.Hex(), id) // Fetches the real CreatedAt to validate DueDate against.
+	if err == ErrTodoNotFound {
+		httpError(w, http.StatusForbidden, errCodeForbidden, "you do not have access to this todo", nil) // Rejects cross-user access instead of revealing whether the todo exists.
+		return
+	}
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to fetch todo", err)
+		return
+	}
+	if failures := validateDueDate(t.DueDate, current.CreatedAt); failures != nil {
+		httpValidationError(w, failures) // Rejects a due date that isn't after the real creation time.
 		return
 	}
 
-	collection := db.Collection(collectionName) // Gets the collection.
-	_, err = collection.UpdateOne(
-		context.TODO(),
-		bson.M{"_id": objID},
-		bson.M{"$set": bson.M{"title": t.Title, "completed": t.Completed}},
-	) // Updates the document.
+	_, err = store.Update(context.TODO(), ownerID.Hex(), id, t.Title, t.Completed, t.DueDate) // Updates the todo only if owned by the caller.
+	if err == ErrTodoNotFound {
+		httpError(w, http.StatusForbidden, errCodeForbidden, "you do not have access to this todo", nil) // Rejects cross-user access instead of revealing whether the todo exists.
+		return
+	}
 	if err != nil {
-		rnd.JSON(w, http.StatusInternalServerError, renderer.M{
-			"message": "failed to update todo",
-			"error":   err,
-		}) // Responds with an error if the update operation fails.
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to update todo", err)
 		return
 	}
 	rnd.JSON(w, http.StatusOK, renderer.M{
@@ -197,16 +256,35 @@ func updateTodo(w http.ResponseWriter, r *http.Request) {
 }
 
 func main() {
-	stopChan := make(chan os.Signal)      // Creates a channel to receive OS signals for graceful shutdown.
-	signal.Notify(stopChan, os.Interrupt) // Notifies the channel on receiving an interrupt signal.
+	cfg := loadConfig()               // Loads configuration from the environment (and .env, if present).
+	rnd = renderer.New()              // Initializes the renderer.
+	jwtSecret = cfg.JWTSecret         // Publishes the JWT signing secret for auth.go to use.
+	cookiesSecure = cfg.CookiesSecure // Publishes whether cookies require TLS for secure_cookie.go/ui.go to use.
+
+	client, err := connectMongo(context.Background(), cfg) // Connects to MongoDB with bounded, retrying dials.
+	checkErr(err)                                          // Fatal only after every retry has been exhausted.
+	db = client.Database(cfg.DBName)                       // Selects the database.
+	checkErr(ensureUserIndexes(context.Background()))      // Ensures the unique email index backing duplicate-registration rejection exists.
+
+	s, err := newStore(context.Background(), cfg) // Builds the TodoStore selected by cfg.StorageBackend.
+	checkErr(err)                                 // Checks for storage initialization errors.
+	store = s                                     // Publishes the store for handlers to use.
+
+	stopChan := make(chan os.Signal, 1)                    // Creates a buffered channel so a signal isn't missed if nothing is receiving yet.
+	signal.Notify(stopChan, os.Interrupt, syscall.SIGTERM) // Notifies the channel on interrupt or a container orchestrator's SIGTERM.
 
-	r := chi.NewRouter()             // Creates a new router using chi.
-	r.Use(middleware.Logger)         // Adds logging middleware to the router.
-	r.Get("/", homeHandler)          // Sets the route for the home handler.
-	r.Mount("/todo", todoHandlers()) // Mounts the todoHandlers under the "/todo" path.
+	r := chi.NewRouter()                     // Creates a new router using chi.
+	r.Use(middleware.RequestID)              // Assigns a request id, read by requestLogger and returned to callers.
+	r.Use(withRequestUser)                   // Gives auth middleware somewhere to record the caller's id for logging.
+	r.Use(requestLogger)                     // Logs one JSON line per request and records the Prometheus HTTP metrics.
+	r.Handle("/metrics", promhttp.Handler()) // Exposes Prometheus metrics for scraping.
+	r.Get("/", homeHandler)                  // Sets the route for the home handler.
+	r.Mount("/auth", authHandlers())         // Mounts the register/login handlers under the "/auth" path.
+	r.Mount("/todo", todoHandlers())         // Mounts the todoHandlers under the "/todo" path.
+	r.Mount("/ui", uiHandlers())             // Mounts the server-rendered HTML UI under the "/ui" path.
 
 	srv := &http.Server{ // Configures the HTTP server.
-		Addr:         port,             // Sets the server address and port.
+		Addr:         cfg.Port,         // Sets the server address and port.
 		Handler:      r,                // Sets the router as the request handler.
 		ReadTimeout:  60 * time.Second, // Sets the maximum duration for reading the entire request.
 		WriteTimeout: 60 * time.Second, // Sets the maximum duration before timing out writes of the response.
@@ -214,22 +292,23 @@ func main() {
 	}
 
 	go func() { // Starts the server in a new goroutine.
-		log.Println("listening on port", port)       // Logs that the server is listening on the specified port.
-		if err := srv.ListenAndServe(); err != nil { // Starts the HTTP server and logs errors if any.
-			log.Printf("listen:%s\n", err)
+		log.Info().Str("port", cfg.Port).Msg("listening") // Logs that the server is listening on the specified port.
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error().Err(err).Msg("listen failed")
 		}
 	}()
 
-	<-stopChan                                                              // Blocks until an interrupt signal is received.
-	log.Println("shutting down server...")                                  // Logs that the server is shutting down.
+	<-stopChan                                                              // Blocks until an interrupt or SIGTERM is received.
+	log.Info().Msg("shutting down server")                                  // Logs that the server is shutting down.
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second) // Creates a context with a 5-second timeout for shutdown.
 	srv.Shutdown(ctx)                                                       // Shuts down the server gracefully.
 	defer cancel()                                                          // Cancels the context to release resources.
-	log.Println("server gracefully stopped!")                               // Logs that the server has been stopped gracefully.
+	log.Info().Msg("server gracefully stopped")                             // Logs that the server has been stopped gracefully.
 }
 
 func todoHandlers() http.Handler {
 	rg := chi.NewRouter()         // Creates a new router group.
+	rg.Use(authMiddleware)        // Requires a valid bearer token for every todo operation.
 	rg.Group(func(r chi.Router) { // Groups routes related to todo operations.
 		r.Get("/", fetchTodos)        // Route for fetching todos.
 		r.Post("/", createTodo)       // Route for creating a new todo.
@@ -241,6 +320,6 @@ func todoHandlers() http.Handler {
 
 func checkErr(err error) {
 	if err != nil {
-		log.Fatal(err) // Logs a fatal error and exits the application.
+		log.Fatal().Err(err).Msg("fatal error") // Logs a fatal error and exits the application.
 	}
 }
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context" // Provides context handling for request-scoped values and cancellation signals.
+	"time"    // For time-related functions.
+
+	"go.mongodb.org/mongo-driver/bson"           // For BSON handling in MongoDB.
+	"go.mongodb.org/mongo-driver/bson/primitive" // For MongoDB ObjectID handling.
+	"go.mongodb.org/mongo-driver/mongo"          // MongoDB driver.
+	"go.mongodb.org/mongo-driver/mongo/options"  // For MongoDB client options.
+)
+
+// mongoStore is the MongoDB-backed TodoStore implementation.
+type mongoStore struct {
+	collection *mongo.Collection // Underlying "todo" collection.
+}
+
+// newMongoStore wraps collection and ensures the text index backing the `q`
+// search parameter exists before handing back a ready-to-use store.
+func newMongoStore(ctx context.Context, collection *mongo.Collection) (*mongoStore, error) {
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "title", Value: "text"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mongoStore{collection: collection}, nil
+}
+
+func (s *mongoStore) List(ctx context.Context, ownerID string, opts ListOptions) ([]Todo, int64, error) {
+	oid, err := primitive.ObjectIDFromHex(ownerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filter := bson.M{"ownerId": oid}
+	if opts.Completed != nil {
+		filter["completed"] = *opts.Completed
+	}
+	if opts.Query != "" {
+		filter["$text"] = bson.M{"$search": opts.Query}
+	}
+
+	countStart := time.Now()
+	total, err := s.collection.CountDocuments(ctx, filter)
+	observeMongoOp("CountDocuments", countStart)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sortOrder := 1
+	if opts.SortDesc {
+		sortOrder = -1
+	}
+	findOpts := options.Find().
+		SetSkip(int64((opts.Page - 1) * opts.Limit)).
+		SetLimit(int64(opts.Limit)).
+		SetSort(bson.D{{Key: opts.SortField, Value: sortOrder}})
+
+	findStart := time.Now()
+	cursor, err := s.collection.Find(ctx, filter, findOpts)
+	observeMongoOp("Find", findStart)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var models []todoModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, 0, err
+	}
+
+	todos := make([]Todo, 0, len(models))
+	for _, m := range models {
+		todos = append(todos, mongoModelToTodo(m))
+	}
+	return todos, total, nil
+}
+
+func (s *mongoStore) Get(ctx context.Context, ownerID, id string) (Todo, error) {
+	oid, err := primitive.ObjectIDFromHex(ownerID)
+	if err != nil {
+		return Todo{}, err
+	}
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Todo{}, err
+	}
+
+	var m todoModel
+	findOneStart := time.Now()
+	err = s.collection.FindOne(ctx, bson.M{"_id": objID, "ownerId": oid}).Decode(&m)
+	observeMongoOp("FindOne", findOneStart)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return Todo{}, ErrTodoNotFound
+		}
+		return Todo{}, err
+	}
+	return mongoModelToTodo(m), nil
+}
+
+func (s *mongoStore) Create(ctx context.Context, t Todo) (Todo, error) {
+	oid, err := primitive.ObjectIDFromHex(t.OwnerID)
+	if err != nil {
+		return Todo{}, err
+	}
+
+	m := todoModel{
+		ID:        primitive.NewObjectID(),
+		OwnerID:   oid,
+		Title:     t.Title,
+		Completed: t.Completed,
+		CreatedAt: t.CreatedAt,
+		DueDate:   t.DueDate,
+	}
+	insertStart := time.Now()
+	_, err = s.collection.InsertOne(ctx, m)
+	observeMongoOp("InsertOne", insertStart)
+	if err != nil {
+		return Todo{}, err
+	}
+	return mongoModelToTodo(m), nil
+}
+
+func (s *mongoStore) Update(ctx context.Context, ownerID, id, title string, completed bool, dueDate *time.Time) (Todo, error) {
+	oid, err := primitive.ObjectIDFromHex(ownerID)
+	if err != nil {
+		return Todo{}, err
+	}
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Todo{}, err
+	}
+
+	updateStart := time.Now()
+	res, err := s.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "ownerId": oid},
+		bson.M{"$set": bson.M{"title": title, "completed": completed, "dueDate": dueDate}},
+	)
+	observeMongoOp("UpdateOne", updateStart)
+	if err != nil {
+		return Todo{}, err
+	}
+	if res.MatchedCount == 0 {
+		return Todo{}, ErrTodoNotFound
+	}
+	return s.Get(ctx, ownerID, id)
+}
+
+func (s *mongoStore) Delete(ctx context.Context, ownerID, id string) error {
+	oid, err := primitive.ObjectIDFromHex(ownerID)
+	if err != nil {
+		return err
+	}
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	deleteStart := time.Now()
+	res, err := s.collection.DeleteOne(ctx, bson.M{"_id": objID, "ownerId": oid})
+	observeMongoOp("DeleteOne", deleteStart)
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrTodoNotFound
+	}
+	return nil
+}
+
+func mongoModelToTodo(m todoModel) Todo {
+	return Todo{
+		ID:        m.ID.Hex(),
+		OwnerID:   m.OwnerID.Hex(),
+		Title:     m.Title,
+		Completed: m.Completed,
+		CreatedAt: m.CreatedAt,
+		DueDate:   m.DueDate,
+	}
+}
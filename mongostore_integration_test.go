@@ -0,0 +1,95 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/mongodb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// newTestMongoStore spins up a real MongoDB container via testcontainers-go
+// and returns a mongoStore backed by it, tearing the container down when the
+// test finishes.
+func newTestMongoStore(t *testing.T) *mongoStore {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := mongodb.RunContainer(ctx, testcontainers.WithImage("mongo:6"))
+	if err != nil {
+		t.Fatalf("failed to start mongodb container: %v", err)
+	}
+	t.Cleanup(func() { container.Terminate(ctx) })
+
+	uri, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatalf("failed to read mongodb connection string: %v", err)
+	}
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("failed to connect to mongodb: %v", err)
+	}
+	t.Cleanup(func() { client.Disconnect(ctx) })
+
+	s, err := newMongoStore(ctx, client.Database("integration_test").Collection("todo"))
+	if err != nil {
+		t.Fatalf("failed to build mongoStore: %v", err)
+	}
+	return s
+}
+
+func TestMongoStoreCRUD(t *testing.T) {
+	ctx := context.Background()
+	s := newTestMongoStore(t)
+	ownerID := primitive.NewObjectID().Hex()
+
+	created, err := s.Create(ctx, Todo{OwnerID: ownerID, Title: "write the report", CreatedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatalf("Create did not assign an id")
+	}
+
+	got, err := s.Get(ctx, ownerID, created.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Title != "write the report" {
+		t.Fatalf("Get returned title %q, want %q", got.Title, "write the report")
+	}
+
+	if _, err := s.Get(ctx, primitive.NewObjectID().Hex(), created.ID); err != ErrTodoNotFound {
+		t.Fatalf("Get for a different owner = %v, want ErrTodoNotFound", err)
+	}
+
+	updated, err := s.Update(ctx, ownerID, created.ID, "write the final report", true, nil)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if !updated.Completed || updated.Title != "write the final report" {
+		t.Fatalf("Update did not apply, got %+v", updated)
+	}
+
+	todos, total, err := s.List(ctx, ownerID, ListOptions{Page: 1, Limit: 20, SortField: "createAt", SortDesc: true})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 1 || len(todos) != 1 {
+		t.Fatalf("List returned %d/%d todos, want 1/1", len(todos), total)
+	}
+
+	if err := s.Delete(ctx, ownerID, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Delete(ctx, ownerID, created.ID); err != ErrTodoNotFound {
+		t.Fatalf("Delete of an already-deleted todo = %v, want ErrTodoNotFound", err)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"  // Provides context handling for request-scoped values and cancellation signals.
+	"net/http" // For HTTP client and server implementations.
+	"time"     // For time-related functions.
+
+	"strconv" // For formatting the HTTP status label.
+
+	"github.com/go-chi/chi"                                   // Lightweight, idiomatic router for building Go HTTP services.
+	"github.com/go-chi/chi/middleware"                        // Middleware for chi router.
+	"github.com/prometheus/client_golang/prometheus"          // For defining and registering Prometheus metrics.
+	"github.com/prometheus/client_golang/prometheus/promauto" // For registering metrics with the default registry in one call.
+	"github.com/rs/zerolog/log"                               // Structured, leveled logging.
+)
+
+var (
+	// httpRequestsTotal counts every request the router serves, labeled by
+	// method, route pattern, and status so operators can slice by endpoint.
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "todo_http_requests_total",
+		Help: "Total number of HTTP requests processed.",
+	}, []string{"method", "route", "status"})
+
+	// httpRequestDuration tracks request latency for the same labels.
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "todo_http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// mongoOperationDuration tracks how long each Mongo collection call
+	// takes, labeled by operation name (e.g. "FindOne", "InsertOne").
+	mongoOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "todo_mongo_operation_duration_seconds",
+		Help:    "MongoDB operation latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+)
+
+// requestUserHolder is stashed in the request context by withRequestUser
+// before routing, then filled in by authMiddleware/uiAuthMiddleware once the
+// caller is identified. Storing a pointer lets requestLogger, which wraps
+// the whole chain, observe the user id even though it runs before auth.
+type requestUserHolder struct {
+	userID string
+}
+
+const requestUserContextKey contextKey = "requestUser"
+
+// withRequestUser installs an empty holder in the request context so
+// downstream auth middleware has somewhere to record the caller's id.
+func withRequestUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), requestUserContextKey, &requestUserHolder{})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// setRequestUser records the authenticated user's id for requestLogger to
+// pick up, if the holder installed by withRequestUser is present.
+func setRequestUser(r *http.Request, userID string) {
+	if holder, ok := r.Context().Value(requestUserContextKey).(*requestUserHolder); ok {
+		holder.userID = userID
+	}
+}
+
+func requestUserFromContext(r *http.Request) string {
+	if holder, ok := r.Context().Value(requestUserContextKey).(*requestUserHolder); ok {
+		return holder.userID
+	}
+	return ""
+}
+
+// requestLogger logs one JSON line per request — request id, user id (once
+// auth has run), method, path, status, duration, and bytes written — and
+// records the same request against the Prometheus metrics above.
+func requestLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		duration := time.Since(start)
+		route := chi.RouteContext(r.Context()).RoutePattern()
+		if route == "" {
+			route = r.URL.Path
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK // WrapResponseWriter only records a status once WriteHeader is called.
+		}
+		statusLabel := strconv.Itoa(status)
+
+		httpRequestsTotal.WithLabelValues(r.Method, route, statusLabel).Inc()
+		httpRequestDuration.WithLabelValues(r.Method, route, statusLabel).Observe(duration.Seconds())
+
+		log.Info().
+			Str("request_id", middleware.GetReqID(r.Context())).
+			Str("user_id", requestUserFromContext(r)).
+			Str("method", r.Method).
+			Str("path", r.URL.Path).
+			Int("status", status).
+			Dur("duration", duration).
+			Int("bytes", ww.BytesWritten()).
+			Msg("request")
+	})
+}
+
+// observeMongoOp records how long a single Mongo collection call took,
+// labeled by operation name. Called as `defer observeMongoOp("FindOne", time.Now())`
+// around each collection.* call.
+func observeMongoOp(operation string, start time.Time) {
+	mongoOperationDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context" // Provides context handling for request-scoped values and cancellation signals.
+	"errors"  // For matching pgx.ErrNoRows without relying on its message text.
+	"fmt"     // For building the dynamic WHERE/ORDER BY clauses.
+	"strings" // For building the dynamic WHERE/ORDER BY clauses.
+	"time"    // For time-related functions.
+
+	"github.com/jackc/pgx/v4"         // For pgx.ErrNoRows.
+	"github.com/jackc/pgx/v4/pgxpool" // Postgres driver and connection pool.
+)
+
+// postgresStore is the Postgres-backed TodoStore implementation.
+type postgresStore struct {
+	pool *pgxpool.Pool // Connection pool to the "demo_todo" database.
+}
+
+// newPostgresStore connects to Postgres using dsn and ensures the "todos"
+// table exists before handing back a ready-to-use store.
+func newPostgresStore(ctx context.Context, dsn string) (*postgresStore, error) {
+	pool, err := pgxpool.Connect(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &postgresStore{pool: pool}
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// migrate creates the todos table if it does not already exist. It is
+// intentionally idempotent so it can run once on every startup instead of
+// requiring a separate migration step.
+func (s *postgresStore) migrate(ctx context.Context) error {
+	_, err := s.pool.Exec(ctx, `
+		CREATE EXTENSION IF NOT EXISTS pgcrypto;
+		CREATE TABLE IF NOT EXISTS todos (
+			id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+			owner_id text NOT NULL,
+			title text NOT NULL,
+			completed boolean NOT NULL DEFAULT false,
+			created_at timestamptz NOT NULL DEFAULT now(),
+			due_date timestamptz
+		);
+	`)
+	return err
+}
+
+// postgresSortColumns maps the API's SortField values to real column names.
+var postgresSortColumns = map[string]string{
+	"createAt": "created_at",
+	"title":    "title",
+}
+
+func (s *postgresStore) List(ctx context.Context, ownerID string, opts ListOptions) ([]Todo, int64, error) {
+	where := []string{"owner_id = $1"}
+	args := []interface{}{ownerID}
+
+	if opts.Completed != nil {
+		args = append(args, *opts.Completed)
+		where = append(where, fmt.Sprintf("completed = $%d", len(args)))
+	}
+	if opts.Query != "" {
+		args = append(args, "%"+opts.Query+"%")
+		where = append(where, fmt.Sprintf("title ILIKE $%d", len(args)))
+	}
+	whereClause := strings.Join(where, " AND ")
+
+	var total int64
+	countSQL := fmt.Sprintf("SELECT count(*) FROM todos WHERE %s", whereClause)
+	if err := s.pool.QueryRow(ctx, countSQL, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	column := postgresSortColumns[opts.SortField]
+	if column == "" {
+		column = "created_at"
+	}
+	order := "ASC"
+	if opts.SortDesc {
+		order = "DESC"
+	}
+
+	args = append(args, opts.Limit, (opts.Page-1)*opts.Limit)
+	listSQL := fmt.Sprintf(
+		"SELECT id, owner_id, title, completed, created_at, due_date FROM todos WHERE %s ORDER BY %s %s LIMIT $%d OFFSET $%d",
+		whereClause, column, order, len(args)-1, len(args),
+	)
+
+	rows, err := s.pool.Query(ctx, listSQL, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var todos []Todo
+	for rows.Next() {
+		var t Todo
+		if err := rows.Scan(&t.ID, &t.OwnerID, &t.Title, &t.Completed, &t.CreatedAt, &t.DueDate); err != nil {
+			return nil, 0, err
+		}
+		todos = append(todos, t)
+	}
+	return todos, total, rows.Err()
+}
+
+func (s *postgresStore) Get(ctx context.Context, ownerID, id string) (Todo, error) {
+	var t Todo
+	err := s.pool.QueryRow(ctx,
+		`SELECT id, owner_id, title, completed, created_at, due_date FROM todos WHERE id = $1 AND owner_id = $2`,
+		id, ownerID).Scan(&t.ID, &t.OwnerID, &t.Title, &t.Completed, &t.CreatedAt, &t.DueDate)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Todo{}, ErrTodoNotFound
+		}
+		return Todo{}, err
+	}
+	return t, nil
+}
+
+func (s *postgresStore) Create(ctx context.Context, t Todo) (Todo, error) {
+	var created Todo
+	err := s.pool.QueryRow(ctx,
+		`INSERT INTO todos (owner_id, title, completed, created_at, due_date) VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id, owner_id, title, completed, created_at, due_date`,
+		t.OwnerID, t.Title, t.Completed, t.CreatedAt, t.DueDate).
+		Scan(&created.ID, &created.OwnerID, &created.Title, &created.Completed, &created.CreatedAt, &created.DueDate)
+	if err != nil {
+		return Todo{}, err
+	}
+	return created, nil
+}
+
+func (s *postgresStore) Update(ctx context.Context, ownerID, id, title string, completed bool, dueDate *time.Time) (Todo, error) {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE todos SET title = $1, completed = $2, due_date = $3 WHERE id = $4 AND owner_id = $5`,
+		title, completed, dueDate, id, ownerID)
+	if err != nil {
+		return Todo{}, err
+	}
+	if tag.RowsAffected() == 0 {
+		return Todo{}, ErrTodoNotFound
+	}
+	return s.Get(ctx, ownerID, id)
+}
+
+func (s *postgresStore) Delete(ctx context.Context, ownerID, id string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM todos WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTodoNotFound
+	}
+	return nil
+}
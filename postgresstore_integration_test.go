@@ -0,0 +1,94 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// newTestPostgresStore spins up a real Postgres container via
+// testcontainers-go and returns a postgresStore backed by it, tearing the
+// container down when the test finishes.
+func newTestPostgresStore(t *testing.T) *postgresStore {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:16-alpine"),
+		postgres.WithDatabase("integration_test"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+	)
+	if err != nil {
+		t.Fatalf("failed to start postgres container: %v", err)
+	}
+	t.Cleanup(func() { container.Terminate(ctx) })
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("failed to read postgres connection string: %v", err)
+	}
+
+	s, err := newPostgresStore(ctx, dsn)
+	if err != nil {
+		t.Fatalf("failed to build postgresStore: %v", err)
+	}
+	return s
+}
+
+func TestPostgresStoreCRUD(t *testing.T) {
+	ctx := context.Background()
+	s := newTestPostgresStore(t)
+	// auth.go always mints Mongo ObjectID-style owner ids, regardless of
+	// STORAGE, so exercise the real format rather than a Postgres uuid.
+	ownerID := primitive.NewObjectID().Hex()
+
+	created, err := s.Create(ctx, Todo{OwnerID: ownerID, Title: "write the report", CreatedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatalf("Create did not assign an id")
+	}
+
+	got, err := s.Get(ctx, ownerID, created.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Title != "write the report" {
+		t.Fatalf("Get returned title %q, want %q", got.Title, "write the report")
+	}
+
+	if _, err := s.Get(ctx, primitive.NewObjectID().Hex(), created.ID); err != ErrTodoNotFound {
+		t.Fatalf("Get for a different owner = %v, want ErrTodoNotFound", err)
+	}
+
+	updated, err := s.Update(ctx, ownerID, created.ID, "write the final report", true, nil)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if !updated.Completed || updated.Title != "write the final report" {
+		t.Fatalf("Update did not apply, got %+v", updated)
+	}
+
+	todos, total, err := s.List(ctx, ownerID, ListOptions{Page: 1, Limit: 20, SortField: "createAt", SortDesc: true})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 1 || len(todos) != 1 {
+		t.Fatalf("List returned %d/%d todos, want 1/1", len(todos), total)
+	}
+
+	if err := s.Delete(ctx, ownerID, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Delete(ctx, ownerID, created.ID); err != ErrTodoNotFound {
+		t.Fatalf("Delete of an already-deleted todo = %v, want ErrTodoNotFound", err)
+	}
+}
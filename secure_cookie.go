@@ -0,0 +1,92 @@
+package main
+
+import (
+	"crypto/rand"   // For generating CSRF tokens.
+	"crypto/subtle" // For constant-time token comparison.
+	"encoding/hex"  // For encoding CSRF tokens as text.
+	"net/http"      // For HTTP client and server implementations.
+	"net/url"       // For encoding flash messages so they survive as cookie values.
+)
+
+const (
+	csrfCookieName  string = "csrf_token" // Cookie carrying the double-submit CSRF token.
+	flashCookieName string = "flash"      // Cookie carrying a one-time flash message.
+)
+
+// cookiesSecure controls the Secure attribute on every cookie the UI sets.
+// It is published by main() from Config.CookiesSecure. Browsers silently
+// drop Secure cookies over plain HTTP, so this must be false unless TLS
+// terminates in front of the app.
+var cookiesSecure bool
+
+// issueCSRFToken generates a fresh CSRF token, stores it in a secure cookie,
+// and returns it so it can also be embedded as a hidden form field.
+func issueCSRFToken(w http.ResponseWriter) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(raw)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     csrfCookieName,
+		Value:    token,
+		Path:     "/ui",
+		HttpOnly: true,
+		Secure:   cookiesSecure,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return token, nil
+}
+
+// validCSRF compares the csrf_token cookie against the csrf_token form field,
+// rejecting the request unless both are present and match.
+func validCSRF(r *http.Request) bool {
+	cookie, err := r.Cookie(csrfCookieName)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+	formToken := r.FormValue("csrf_token")
+	if formToken == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(formToken)) == 1
+}
+
+// setFlash stores a one-time flash message in a secure cookie, read and
+// cleared by the next request via popFlash.
+func setFlash(w http.ResponseWriter, message string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     flashCookieName,
+		Value:    url.QueryEscape(message),
+		Path:     "/ui",
+		HttpOnly: true,
+		Secure:   cookiesSecure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// popFlash reads the flash cookie, if any, and clears it so the message is
+// only ever shown once.
+func popFlash(w http.ResponseWriter, r *http.Request) string {
+	cookie, err := r.Cookie(flashCookieName)
+	if err != nil || cookie.Value == "" {
+		return ""
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     flashCookieName,
+		Value:    "",
+		Path:     "/ui",
+		HttpOnly: true,
+		Secure:   cookiesSecure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+	})
+
+	message, err := url.QueryUnescape(cookie.Value)
+	if err != nil {
+		return ""
+	}
+	return message
+}
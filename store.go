@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context" // Provides context handling for request-scoped values and cancellation signals.
+	"errors"  // For defining sentinel errors.
+	"time"    // For time-related functions.
+)
+
+// ErrTodoNotFound is returned by a TodoStore when no todo matches the given
+// id for the given owner — either because it was never created or because
+// it belongs to a different user.
+var ErrTodoNotFound = errors.New("todo not found")
+
+// Todo is the storage-agnostic representation of a to-do item, shared by
+// every TodoStore implementation.
+type Todo struct {
+	ID        string     // Backend-specific identifier (a Mongo ObjectID hex or a Postgres uuid).
+	OwnerID   string     // Identifier of the user the todo belongs to.
+	Title     string     // Title of the to-do item.
+	Completed bool       // Completion status.
+	CreatedAt time.Time  // Creation timestamp.
+	DueDate   *time.Time // Optional due date.
+}
+
+// ListOptions controls pagination, filtering, sorting, and full-text search
+// for TodoStore.List. Page and Limit are both 1-based/positive; SortField is
+// one of "createAt" or "title".
+type ListOptions struct {
+	Page      int    // 1-based page number.
+	Limit     int    // Page size, capped at maxListLimit.
+	SortField string // "createAt" or "title".
+	SortDesc  bool   // true for "desc", false for "asc".
+	Completed *bool  // nil means "don't filter by completion status".
+	Query     string // Free-text search against the todo's title.
+}
+
+// maxListLimit is the largest page size fetchTodos will accept.
+const maxListLimit int = 100
+
+// TodoStore abstracts the persistence of todos so handlers never depend on a
+// specific database driver.
+type TodoStore interface {
+	List(ctx context.Context, ownerID string, opts ListOptions) ([]Todo, int64, error)
+	Get(ctx context.Context, ownerID, id string) (Todo, error)
+	Create(ctx context.Context, t Todo) (Todo, error)
+	Update(ctx context.Context, ownerID, id, title string, completed bool, dueDate *time.Time) (Todo, error)
+	Delete(ctx context.Context, ownerID, id string) error
+}
+
+// newStore builds the TodoStore selected by cfg.StorageBackend ("mongo" or
+// "postgres").
+func newStore(ctx context.Context, cfg Config) (TodoStore, error) {
+	switch cfg.StorageBackend {
+	case "postgres":
+		return newPostgresStore(ctx, cfg.PostgresDSN)
+	default:
+		return newMongoStore(ctx, db.Collection(cfg.CollectionName))
+	}
+}
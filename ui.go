@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"  // Provides context handling for request-scoped values and cancellation signals.
+	"net/http" // For HTTP client and server implementations.
+
+	"github.com/go-chi/chi"                      // Lightweight, idiomatic router for building Go HTTP services.
+	"go.mongodb.org/mongo-driver/bson/primitive" // For MongoDB ObjectID handling.
+)
+
+const sessionCookieName string = "session_token" // Cookie carrying the signed-in user's JWT for the HTML UI.
+
+type uiPageData struct {
+	Rows      []todoRowData // One entry per todo, each carrying its own copy of CSRFToken.
+	CSRFToken string        // Token to embed in the "add todo" form.
+	Flash     string        // One-time message to render, if any.
+}
+
+// todoRowData is the argument passed to the "todo_row" template. {{template}}
+// rebinds $ to whatever is passed in, so todo_row can't reach back up to the
+// page-level CSRFToken on its own; it has to be handed its own copy.
+type todoRowData struct {
+	Todo      todo   // The todo this row renders.
+	CSRFToken string // Token to embed in this row's toggle/delete forms.
+}
+
+type uiLoginData struct {
+	CSRFToken string // Token to embed in the login form.
+	Flash     string // One-time message to render, if any.
+	Error     string // Validation/authentication error, if any.
+}
+
+// uiAuthMiddleware mirrors authMiddleware but reads the JWT from the UI's
+// session cookie instead of the Authorization header, redirecting to the
+// login form when it is missing or invalid.
+func uiAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(sessionCookieName)
+		if err != nil || cookie.Value == "" {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		userID, err := userIDFromToken(cookie.Value)
+		if err != nil {
+			http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+			return
+		}
+
+		setRequestUser(r, userID.Hex()) // Lets requestLogger report the caller even though it ran before auth.
+		ctx := context.WithValue(r.Context(), userIDContextKey, userID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func uiLoginPageHandler(w http.ResponseWriter, r *http.Request) {
+	token, err := issueCSRFToken(w)
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to issue csrf token", err)
+		return
+	}
+
+	err = rnd.Template(w, http.StatusOK, []string{"templates/layout.tpl", "templates/login.tpl"}, uiLoginData{
+		CSRFToken: token,
+		Flash:     popFlash(w, r),
+	})
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to render page", err)
+		return
+	}
+}
+
+func uiLoginHandler(w http.ResponseWriter, r *http.Request) {
+	if !validCSRF(r) {
+		httpError(w, http.StatusForbidden, errCodeForbidden, "invalid csrf token", nil)
+		return
+	}
+
+	email := r.FormValue("email")
+	password := r.FormValue("password")
+
+	u, err := authenticate(email, password)
+	if err != nil {
+		token, tokenErr := issueCSRFToken(w)
+		if tokenErr != nil {
+			httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to issue csrf token", tokenErr)
+			return
+		}
+		if err := rnd.Template(w, http.StatusUnauthorized, []string{"templates/layout.tpl", "templates/login.tpl"}, uiLoginData{
+			CSRFToken: token,
+			Error:     "invalid email or password",
+		}); err != nil {
+			httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to render page", err)
+		}
+		return
+	}
+
+	jwtToken, err := mintToken(u.ID)
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to mint token", err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    jwtToken,
+		Path:     "/ui",
+		HttpOnly: true,
+		Secure:   cookiesSecure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(tokenTTL.Seconds()),
+	})
+	http.Redirect(w, r, "/ui", http.StatusSeeOther)
+}
+
+func uiListHandler(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := userIDFromContext(r)
+	if !ok {
+		http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+		return
+	}
+
+	todos, _, err := store.List(context.TODO(), ownerID.Hex(), ListOptions{
+		Page: 1, Limit: maxListLimit, SortField: "createAt", SortDesc: true,
+	})
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to fetch todos", err)
+		return
+	}
+
+	csrfToken, err := issueCSRFToken(w)
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to issue csrf token", err)
+		return
+	}
+
+	var rows []todoRowData
+	for _, t := range todos {
+		rows = append(rows, todoRowData{
+			Todo:      todo{ID: t.ID, Title: t.Title, Completed: t.Completed, CreatedAt: t.CreatedAt},
+			CSRFToken: csrfToken,
+		})
+	}
+
+	err = rnd.Template(w, http.StatusOK, []string{"templates/layout.tpl", "templates/todo_list.tpl", "templates/todo_row.tpl"}, uiPageData{
+		Rows:      rows,
+		CSRFToken: csrfToken,
+		Flash:     popFlash(w, r),
+	})
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to render page", err)
+		return
+	}
+}
+
+func uiCreateHandler(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := userIDFromContext(r)
+	if !ok {
+		http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+		return
+	}
+	if !validCSRF(r) {
+		httpError(w, http.StatusForbidden, errCodeForbidden, "invalid csrf token", nil)
+		return
+	}
+
+	title := r.FormValue("title")
+	if title == "" {
+		setFlash(w, "The title is required")
+		http.Redirect(w, r, "/ui", http.StatusSeeOther)
+		return
+	}
+
+	if _, err := store.Create(context.TODO(), Todo{OwnerID: ownerID.Hex(), Title: title}); err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to save todo", err)
+		return
+	}
+
+	setFlash(w, "Todo created")
+	http.Redirect(w, r, "/ui", http.StatusSeeOther)
+}
+
+func uiToggleHandler(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := userIDFromContext(r)
+	if !ok {
+		http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+		return
+	}
+	if !validCSRF(r) {
+		httpError(w, http.StatusForbidden, errCodeForbidden, "invalid csrf token", nil)
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	current, err := store.Get(context.TODO(), ownerID.Hex(), id)
+	if err == ErrTodoNotFound {
+		httpError(w, http.StatusForbidden, errCodeForbidden, "you do not have access to this todo", nil)
+		return
+	}
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to fetch todo", err)
+		return
+	}
+
+	if _, err := store.Update(context.TODO(), ownerID.Hex(), id, current.Title, !current.Completed, current.DueDate); err != nil {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to update todo", err)
+		return
+	}
+
+	http.Redirect(w, r, "/ui", http.StatusSeeOther)
+}
+
+func uiDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := userIDFromContext(r)
+	if !ok {
+		http.Redirect(w, r, "/ui/login", http.StatusSeeOther)
+		return
+	}
+	if !validCSRF(r) {
+		httpError(w, http.StatusForbidden, errCodeForbidden, "invalid csrf token", nil)
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if err := store.Delete(context.TODO(), ownerID.Hex(), id); err != nil && err != ErrTodoNotFound {
+		httpError(w, http.StatusInternalServerError, errCodeInternal, "failed to delete todo", err)
+		return
+	}
+
+	setFlash(w, "Todo deleted")
+	http.Redirect(w, r, "/ui", http.StatusSeeOther)
+}
+
+func uiHandlers() http.Handler {
+	rg := chi.NewRouter()
+	rg.Get("/login", uiLoginPageHandler)
+	rg.Post("/login", uiLoginHandler)
+
+	rg.Group(func(r chi.Router) {
+		r.Use(uiAuthMiddleware)
+		r.Get("/", uiListHandler)
+		r.Post("/todo", uiCreateHandler)
+		r.Post("/todo/{id}/toggle", uiToggleHandler)
+		r.Post("/todo/{id}/delete", uiDeleteHandler)
+	})
+	return rg
+}
+
+// userIDFromToken parses and validates a JWT exactly like authMiddleware,
+// returning the embedded user ObjectID.
+func userIDFromToken(raw string) (primitive.ObjectID, error) {
+	c := &claims{}
+	token, err := parseToken(raw, c)
+	if err != nil {
+		return primitive.ObjectID{}, err
+	}
+	if !token.Valid {
+		return primitive.ObjectID{}, errInvalidToken
+	}
+	return primitive.ObjectIDFromHex(c.UserID)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"time" // For comparing DueDate against the real creation time.
+
+	validator "github.com/go-playground/validator/v10" // Struct-tag based request validation.
+)
+
+var validate = validator.New() // Shared validator instance, reused across handlers.
+
+// validationFailure describes one field that failed validation.
+type validationFailure struct {
+	Field string `json:"field"` // Name of the offending field.
+	Tag   string `json:"tag"`   // The validator tag that failed (e.g. "required", "max").
+}
+
+// validationFailures converts a validator error into a client-friendly list,
+// or nil if err is not a validation error (a programmer error such as a
+// non-struct argument).
+func validationFailures(err error) []validationFailure {
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil
+	}
+
+	failures := make([]validationFailure, 0, len(verrs))
+	for _, fe := range verrs {
+		failures = append(failures, validationFailure{Field: fe.Field(), Tag: fe.Tag()})
+	}
+	return failures
+}
+
+// validateDueDate checks that dueDate, if set, falls after createdAt. It
+// takes createdAt as a plain argument rather than a struct-tag comparison
+// because the caller-supplied todo.CreatedAt isn't the real creation time —
+// handlers must pass in the timestamp the store actually persisted.
+func validateDueDate(dueDate *time.Time, createdAt time.Time) []validationFailure {
+	if dueDate == nil || dueDate.After(createdAt) {
+		return nil
+	}
+	return []validationFailure{{Field: "DueDate", Tag: "gtfield=CreatedAt"}}
+}
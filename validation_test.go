@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateTodo(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		name    string
+		in      todo
+		wantErr bool
+	}{
+		{
+			name: "valid todo",
+			in:   todo{Title: "Buy milk", CreatedAt: now},
+		},
+		{
+			name:    "missing title",
+			in:      todo{Title: "", CreatedAt: now},
+			wantErr: true,
+		},
+		{
+			name:    "title too long",
+			in:      todo{Title: stringOfLen(201), CreatedAt: now},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validate.Struct(tc.in)
+			if tc.wantErr && err == nil {
+				t.Fatalf("expected a validation error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("expected no validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateDueDate(t *testing.T) {
+	now := time.Now()
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	cases := []struct {
+		name      string
+		dueDate   *time.Time
+		createdAt time.Time
+		wantErr   bool
+	}{
+		{name: "no due date", dueDate: nil, createdAt: now},
+		{name: "due date after created at", dueDate: &after, createdAt: now},
+		{name: "due date before created at", dueDate: &before, createdAt: now, wantErr: true},
+		{name: "due date equal to created at", dueDate: &now, createdAt: now, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			failures := validateDueDate(tc.dueDate, tc.createdAt)
+			if tc.wantErr && failures == nil {
+				t.Fatalf("expected a validation failure, got nil")
+			}
+			if !tc.wantErr && failures != nil {
+				t.Fatalf("expected no validation failure, got %v", failures)
+			}
+		})
+	}
+}
+
+func TestValidationFailures(t *testing.T) {
+	err := validate.Struct(todo{Title: ""})
+	failures := validationFailures(err)
+	if len(failures) != 1 {
+		t.Fatalf("expected 1 failure, got %d: %v", len(failures), failures)
+	}
+	if failures[0].Field != "Title" || failures[0].Tag != "required" {
+		t.Fatalf("unexpected failure: %+v", failures[0])
+	}
+
+	if got := validationFailures(nil); got != nil {
+		t.Fatalf("expected nil for a non-validation error, got %v", got)
+	}
+}
+
+func stringOfLen(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = 'a'
+	}
+	return string(b)
+}